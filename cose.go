@@ -0,0 +1,234 @@
+package didkey
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"math/big"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// COSE_Key kty, crv and alg values for the EC2 and OKP key types, RFC 8152
+// §7/§13. The parameter labels themselves are the coseKey struct tags.
+const (
+	coseKtyEC2 = 2
+	coseKtyOKP = 1
+
+	coseCrvP256      = 1
+	coseCrvP384      = 2
+	coseCrvP521      = 3
+	coseCrvX25519    = 4
+	coseCrvEd25519   = 6
+	coseCrvSecp256k1 = 8
+
+	coseAlgES256  = -7
+	coseAlgES384  = -35
+	coseAlgES512  = -36
+	coseAlgEdDSA  = -8
+	coseAlgES256K = -47
+)
+
+// coseKey is the CBOR map backing a COSE_Key for the EC2 and OKP key types,
+// keyed by their RFC 8152 integer labels rather than JSON field names.
+type coseKey struct {
+	Kty int    `cbor:"1,keyasint"`
+	Alg int    `cbor:"3,keyasint,omitempty"`
+	Crv int    `cbor:"-1,keyasint"`
+	X   []byte `cbor:"-2,keyasint"`
+	Y   []byte `cbor:"-3,keyasint,omitempty"`
+	D   []byte `cbor:"-4,keyasint,omitempty"`
+}
+
+// COSEKey encodes did as a CBOR COSE_Key, including the private "d"
+// parameter (label -4) when did carries a private key.
+func (did DIDKey) COSEKey() ([]byte, error) {
+	k, err := coseKeyFromDIDKey(did)
+	if err != nil {
+		return nil, err
+	}
+
+	return cbor.Marshal(k)
+}
+
+// ParseCOSEKey decodes a CBOR COSE_Key into a DIDKey, validating that the
+// advertised curve matches the key type, that coordinates fit the curve's
+// field size, and that they are non-zero and on the curve.
+func ParseCOSEKey(data []byte) (*DIDKey, error) {
+	var k coseKey
+	if err := cbor.Unmarshal(data, &k); err != nil {
+		return nil, err
+	}
+
+	switch k.Kty {
+	case coseKtyEC2:
+		return ecDIDKeyFromCOSE(&k)
+	case coseKtyOKP:
+		return okpDIDKeyFromCOSE(&k)
+	default:
+		return nil, fmt.Errorf("cose: unsupported kty %d", k.Kty)
+	}
+}
+
+func coseKeyFromDIDKey(did DIDKey) (*coseKey, error) {
+	switch pub := did.PublicKey.(type) {
+	case *ecdsaPublicKey:
+		return ecCOSEKey(pub.curve, pub.x, pub.y, did.PrivateKey)
+	case *secp256k1PublicKey:
+		return ecCOSEKey(pub.curve, pub.x, pub.y, did.PrivateKey)
+	case *ed25519PublicKey:
+		k := &coseKey{Kty: coseKtyOKP, Alg: coseAlgEdDSA, Crv: coseCrvEd25519, X: pub.key}
+		if priv, ok := did.PrivateKey.(*ed25519PrivateKey); ok {
+			k.D = priv.key.Seed()
+		}
+		return k, nil
+	case *x25519PublicKey:
+		k := &coseKey{Kty: coseKtyOKP, Crv: coseCrvX25519, X: pub.key}
+		if priv, ok := did.PrivateKey.(*x25519PrivateKey); ok {
+			k.D = priv.key
+		}
+		return k, nil
+	default:
+		return nil, fmt.Errorf("cose: unsupported key type %T", did.PublicKey)
+	}
+}
+
+func ecCOSEKey(curve ecdsaCurve, x, y *big.Int, priv PrivateKey) (*coseKey, error) {
+	crv, alg, err := coseCurveParams(curve.codec)
+	if err != nil {
+		return nil, err
+	}
+
+	byteSize := curveByteSize(curve.curve)
+	xb, yb := make([]byte, byteSize), make([]byte, byteSize)
+	x.FillBytes(xb)
+	y.FillBytes(yb)
+
+	k := &coseKey{Kty: coseKtyEC2, Alg: alg, Crv: crv, X: xb, Y: yb}
+
+	var d *big.Int
+	switch priv := priv.(type) {
+	case *ecdsaPrivateKey:
+		d = priv.d
+	case *secp256k1PrivateKey:
+		d = priv.d
+	}
+	if d != nil {
+		db := make([]byte, byteSize)
+		d.FillBytes(db)
+		k.D = db
+	}
+
+	return k, nil
+}
+
+func coseCurveParams(codec Code) (crv, alg int, err error) {
+	switch codec {
+	case P256Pub:
+		return coseCrvP256, coseAlgES256, nil
+	case P384Pub:
+		return coseCrvP384, coseAlgES384, nil
+	case P521Pub:
+		return coseCrvP521, coseAlgES512, nil
+	case Secp256k1Pub:
+		return coseCrvSecp256k1, coseAlgES256K, nil
+	default:
+		return 0, 0, fmt.Errorf("cose: unsupported multicodec %d", codec)
+	}
+}
+
+func ecdsaCurveFromCOSECrv(crv int) (ecdsaCurve, error) {
+	switch crv {
+	case coseCrvP256:
+		return p256Curve, nil
+	case coseCrvP384:
+		return p384Curve, nil
+	case coseCrvP521:
+		return p521Curve, nil
+	case coseCrvSecp256k1:
+		return secp256k1Curve, nil
+	default:
+		return ecdsaCurve{}, fmt.Errorf("cose: unsupported crv %d", crv)
+	}
+}
+
+func ecDIDKeyFromCOSE(k *coseKey) (*DIDKey, error) {
+	curve, err := ecdsaCurveFromCOSECrv(k.Crv)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(k.X) == 0 || len(k.Y) == 0 {
+		return nil, fmt.Errorf("cose: EC2 key requires x and y")
+	}
+
+	byteSize := curveByteSize(curve.curve)
+	if len(k.X) != byteSize || len(k.Y) != byteSize {
+		return nil, fmt.Errorf("cose: x/y must be %d bytes for crv %d", byteSize, k.Crv)
+	}
+
+	x, y := new(big.Int).SetBytes(k.X), new(big.Int).SetBytes(k.Y)
+	if x.Sign() == 0 || y.Sign() == 0 {
+		return nil, fmt.Errorf("cose: x/y must be non-zero")
+	}
+	// IsOnCurve reduces its inputs mod P instead of rejecting them, so a
+	// non-canonical x or y (e.g. x0+P, still byteSize bytes) would otherwise
+	// be silently accepted as equivalent to x0. btcec's secp256k1 does this;
+	// reject out-of-range coordinates ourselves before trusting IsOnCurve.
+	p := curve.curve.Params().P
+	if x.Cmp(p) >= 0 || y.Cmp(p) >= 0 {
+		return nil, fmt.Errorf("cose: x/y must be less than the field prime")
+	}
+	if !curve.curve.IsOnCurve(x, y) {
+		return nil, fmt.Errorf("cose: point is not on the curve")
+	}
+
+	pub := wrapECDSAPublicKey(curve, x, y)
+
+	if len(k.D) == 0 {
+		return &DIDKey{PublicKey: pub}, nil
+	}
+	if len(k.D) != byteSize {
+		return nil, fmt.Errorf("cose: d must be %d bytes for crv %d", byteSize, k.Crv)
+	}
+
+	priv := wrapECDSAPrivateKey(curve, new(big.Int).SetBytes(k.D))
+
+	return &DIDKey{PublicKey: priv.Public(), PrivateKey: priv}, nil
+}
+
+func okpDIDKeyFromCOSE(k *coseKey) (*DIDKey, error) {
+	if len(k.X) == 0 {
+		return nil, fmt.Errorf("cose: OKP key requires x")
+	}
+
+	switch k.Crv {
+	case coseCrvEd25519:
+		if len(k.X) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("cose: Ed25519 x must be %d bytes", ed25519.PublicKeySize)
+		}
+		if len(k.D) == 0 {
+			return &DIDKey{PublicKey: &ed25519PublicKey{key: ed25519.PublicKey(k.X)}}, nil
+		}
+		priv, err := newEd25519PrivateKey(k.D)
+		if err != nil {
+			return nil, err
+		}
+		return &DIDKey{PublicKey: priv.Public(), PrivateKey: priv}, nil
+
+	case coseCrvX25519:
+		if len(k.X) != x25519KeySize {
+			return nil, fmt.Errorf("cose: X25519 x must be %d bytes", x25519KeySize)
+		}
+		if len(k.D) == 0 {
+			return &DIDKey{PublicKey: &x25519PublicKey{key: k.X}}, nil
+		}
+		priv, err := newX25519PrivateKey(k.D)
+		if err != nil {
+			return nil, err
+		}
+		return &DIDKey{PublicKey: priv.Public(), PrivateKey: priv}, nil
+
+	default:
+		return nil, fmt.Errorf("cose: unsupported crv %d", k.Crv)
+	}
+}
@@ -0,0 +1,75 @@
+package didkey
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestCOSEKeyRoundTrip(t *testing.T) {
+	cases := []struct {
+		name     string
+		keyType  KeyType
+		byteSize int
+		op       KeyOp
+	}{
+		{"P256", KeyTypeP256, 32, KeyOpSign},
+		{"Secp256k1", KeyTypeSecp256k1, 32, KeyOpSign},
+		{"Ed25519", KeyTypeEd25519, 32, KeyOpSign},
+		{"X25519", KeyTypeX25519, 32, KeyOpDeriveKey},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			did, err := NewDIDKeyFromPrivateKey(tc.keyType, randomPrivateKey(t, tc.keyType, tc.byteSize), tc.op)
+			if err != nil {
+				t.Fatalf("NewDIDKeyFromPrivateKey: %v", err)
+			}
+
+			encoded, err := did.COSEKey()
+			if err != nil {
+				t.Fatalf("COSEKey: %v", err)
+			}
+
+			decoded, err := ParseCOSEKey(encoded)
+			if err != nil {
+				t.Fatalf("ParseCOSEKey: %v", err)
+			}
+
+			if decoded.DID() != did.DID() {
+				t.Fatalf("round trip mismatch: got %q, want %q", decoded.DID(), did.DID())
+			}
+			if decoded.PrivateKey == nil {
+				t.Fatalf("expected round-tripped COSE_Key to carry a private key")
+			}
+		})
+	}
+}
+
+func TestParseCOSEKeyRejectsNonCanonicalSecp256k1Coordinate(t *testing.T) {
+	x0, y0 := secp256k1SmallXPoint(t)
+
+	p := secp256k1Curve.curve.Params().P
+	byteSize := curveByteSize(secp256k1Curve.curve)
+
+	overflowedX := new(big.Int).Add(x0, p)
+	xb := make([]byte, byteSize)
+	overflowedX.FillBytes(xb)
+
+	yb := make([]byte, byteSize)
+	y0.FillBytes(yb)
+
+	k := &coseKey{Kty: coseKtyEC2, Alg: coseAlgES256K, Crv: coseCrvSecp256k1, X: xb, Y: yb}
+
+	if _, err := ecDIDKeyFromCOSE(k); err == nil {
+		t.Fatalf("expected x = x0+P to be rejected, got nil error")
+	}
+}
+
+func TestParseCOSEKeyRejectsMalformedInput(t *testing.T) {
+	if _, err := ParseCOSEKey([]byte{0xa1, 0x01, 0x05}); err == nil {
+		t.Fatalf("expected unsupported kty to be rejected")
+	}
+	if _, err := ParseCOSEKey([]byte("not cbor")); err == nil {
+		t.Fatalf("expected invalid CBOR to be rejected")
+	}
+}
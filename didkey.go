@@ -1,23 +1,24 @@
 package didkey
 
 import (
-	"crypto/ecdsa"
-	"crypto/elliptic"
-	"crypto/rand"
 	"fmt"
-	"math/big"
 	"strings"
 
 	"github.com/btcsuite/btcd/btcutil/base58"
-	"github.com/yum45f/multicodec"
 )
 
+// DIDKey represents a did:key identifier. PublicKey is always set; PrivateKey
+// is nil when the DIDKey was constructed from a did:key string rather than
+// from private key material.
 type DIDKey struct {
-	PublicKey  ecdsa.PublicKey
-	PrivateKey *ecdsa.PrivateKey
+	PublicKey  PublicKey
+	PrivateKey PrivateKey
 }
 
-func NewDIDKeyFromDID(did string) (*DIDKey, error) {
+// NewDIDKeyFromDID decodes a did:key string and checks the resulting DIDKey
+// against op with Validate. The DIDKey does not record op, so later calls
+// on it are not restricted to op.
+func NewDIDKeyFromDID(did string, op KeyOp) (*DIDKey, error) {
 	splited := strings.Split(did, ":")
 	if len(splited) != 3 {
 		return nil, fmt.Errorf("invalid did format")
@@ -41,105 +42,84 @@ func NewDIDKeyFromDID(did string) (*DIDKey, error) {
 	}
 	decoded := base58.Decode(id[1:])
 
-	// check if this key is supported -- currently only P256Pub is supported
-	code, bytes, err := multicodec.ParseMulticodec(decoded)
+	code, bytes, err := ParseMulticodec(decoded)
 	if err != nil {
 		return nil, err
 	}
-	if code != multicodec.P256Pub {
-		return nil, fmt.Errorf("multicodec not supported; code: %d", code)
-	}
 	if bytes == nil {
 		return nil, fmt.Errorf("invalid did key; decoded bytes must not be nil")
 	}
-	if len(bytes) != 33 {
-		return nil, fmt.Errorf("invalid did key; decoded bytes must be 33 bytes")
+
+	parse, ok := codecs[code]
+	if !ok {
+		return nil, fmt.Errorf("multicodec not supported; code: %d", code)
+	}
+
+	pub, err := parse(bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &DIDKey{PublicKey: pub}
+	if err := key.Validate(op); err != nil {
+		return nil, err
 	}
 
-	x, y := elliptic.UnmarshalCompressed(elliptic.P256(), bytes)
-	return &DIDKey{
-		PublicKey: ecdsa.PublicKey{
-			Curve: elliptic.P256(),
-			X:     x,
-			Y:     y,
-		},
-		PrivateKey: nil,
-	}, nil
+	return key, nil
 }
 
-func NewDIDKeyFromPrivateKey(privateKey []byte) (*DIDKey, error) {
-	x, y := elliptic.P256().ScalarBaseMult(privateKey)
+// NewDIDKeyFromPrivateKey builds a DIDKey from raw private key material and
+// checks it against op with Validate. The DIDKey does not record op, so
+// later calls on it are not restricted to op. keyType selects which curve/signature scheme the bytes are
+// interpreted as; the expected length and encoding (scalar, seed, ...)
+// depends on the key type.
+func NewDIDKeyFromPrivateKey(keyType KeyType, privateKey []byte, op KeyOp) (*DIDKey, error) {
+	priv, err := newPrivateKeyFromBytes(keyType, privateKey)
+	if err != nil {
+		return nil, err
+	}
 
-	pubKey := ecdsa.PublicKey{
-		Curve: elliptic.P256(),
-		X:     x,
-		Y:     y,
+	key := &DIDKey{PublicKey: priv.Public(), PrivateKey: priv}
+	if err := key.Validate(op); err != nil {
+		return nil, err
 	}
 
-	return &DIDKey{
-		PublicKey: pubKey,
-		PrivateKey: &ecdsa.PrivateKey{
-			PublicKey: pubKey,
-			D:         new(big.Int).SetBytes(privateKey),
-		},
-	}, nil
+	return key, nil
 }
 
 func (did DIDKey) DID() string {
 	encoded := base58.Encode(
-		multicodec.EncodeMulticodec(
-			multicodec.P256Pub,
-			elliptic.MarshalCompressed(elliptic.P256(), did.PublicKey.X, did.PublicKey.Y),
+		EncodeMulticodec(
+			did.PublicKey.Multicodec(),
+			did.PublicKey.Marshal(),
 		),
 	)
 
 	return fmt.Sprintf("did:key:z%s", encoded)
 }
 
-func (did DIDKey) Verify(digest [32]byte, signature []byte) bool {
-	if did.PublicKey.Curve != elliptic.P256() {
-		return false
-	}
-
-	curveByteSize := did.PublicKey.Curve.Params().BitSize / 8
-	if did.PublicKey.Curve.Params().BitSize/8%8 > 0 {
-		curveByteSize += 1
-	}
-
-	if len(signature) != curveByteSize*2 {
-		return false
-	}
-
-	r := new(big.Int).SetBytes(signature[:curveByteSize])
-	s := new(big.Int).SetBytes(signature[curveByteSize:])
-
-	return ecdsa.Verify(&did.PublicKey, digest[:], r, s)
+// Verify reports whether signature is a valid signature of msg under did,
+// hashing msg with whatever SignatureScheme did's key type uses.
+func (did DIDKey) Verify(msg, signature []byte) bool {
+	return did.PublicKey.Verify(msg, signature)
 }
 
-func (did DIDKey) Sign(digest [32]byte) ([]byte, error) {
+// Sign signs msg, returning a signature verifiable with Verify.
+func (did DIDKey) Sign(msg []byte) ([]byte, error) {
 	if did.PrivateKey == nil {
 		return nil, fmt.Errorf("failed to sign; private key not found")
 	}
-	if did.PrivateKey.Curve != elliptic.P256() {
-		return nil, fmt.Errorf("failed to sign; curve must be P256")
-	}
-
-	key := did.PrivateKey
 
-	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
-	if err != nil {
-		return nil, err
-	}
+	return did.PrivateKey.Sign(msg)
+}
 
-	curveByteSize := key.Curve.Params().BitSize / 8
-	if key.Curve.Params().BitSize/8%8 > 0 {
-		curveByteSize += 1
+// SignDeterministic signs msg the same way Sign does, but derives its nonce
+// deterministically instead of reading from a random source, so signing the
+// same message twice produces the same signature.
+func (did DIDKey) SignDeterministic(msg []byte) ([]byte, error) {
+	if did.PrivateKey == nil {
+		return nil, fmt.Errorf("failed to sign; private key not found")
 	}
 
-	sig := make([]byte, curveByteSize*2)
-
-	r.FillBytes(sig[0:curveByteSize])
-	s.FillBytes(sig[curveByteSize:])
-
-	return sig, nil
+	return did.PrivateKey.SignDeterministic(msg)
 }
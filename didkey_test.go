@@ -0,0 +1,161 @@
+package didkey
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+// randomPrivateKey returns byteSize random bytes suitable as private key
+// material for keyType, resampling out-of-range scalars the way
+// crypto/ecdsa.GenerateKey does: P-521's order is a few bits narrower than
+// its 66-byte encoding, so a uniformly random 66-byte buffer is out of
+// range (>= N) well over 99% of the time.
+func randomPrivateKey(t *testing.T, keyType KeyType, byteSize int) []byte {
+	t.Helper()
+
+	n := scalarOrderFor(keyType)
+
+	for {
+		buf := make([]byte, byteSize)
+		if _, err := rand.Read(buf); err != nil {
+			t.Fatalf("rand.Read: %v", err)
+		}
+		if n == nil {
+			return buf
+		}
+
+		d := new(big.Int).SetBytes(buf)
+		if d.Sign() > 0 && d.Cmp(n) < 0 {
+			return buf
+		}
+	}
+}
+
+// scalarOrderFor returns the curve order private key material for keyType
+// must be reduced modulo, or nil for key types with no such constraint
+// (Ed25519/X25519 scalars are clamped seeds, not bounded integers).
+func scalarOrderFor(keyType KeyType) *big.Int {
+	switch keyType {
+	case KeyTypeP256:
+		return p256Curve.curve.Params().N
+	case KeyTypeP384:
+		return p384Curve.curve.Params().N
+	case KeyTypeP521:
+		return p521Curve.curve.Params().N
+	case KeyTypeSecp256k1:
+		return secp256k1Curve.curve.Params().N
+	default:
+		return nil
+	}
+}
+
+func TestNewDIDKeyFromPrivateKeyRoundTrip(t *testing.T) {
+	cases := []struct {
+		name     string
+		keyType  KeyType
+		byteSize int
+		op       KeyOp
+	}{
+		{"P256", KeyTypeP256, 32, KeyOpVerify},
+		{"P384", KeyTypeP384, 48, KeyOpVerify},
+		{"P521", KeyTypeP521, 66, KeyOpVerify},
+		{"Secp256k1", KeyTypeSecp256k1, 32, KeyOpVerify},
+		{"Ed25519", KeyTypeEd25519, 32, KeyOpVerify},
+		{"X25519", KeyTypeX25519, 32, KeyOpDeriveKey},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			did, err := NewDIDKeyFromPrivateKey(tc.keyType, randomPrivateKey(t, tc.keyType, tc.byteSize), tc.op)
+			if err != nil {
+				t.Fatalf("NewDIDKeyFromPrivateKey: %v", err)
+			}
+
+			encoded := did.DID()
+
+			decoded, err := NewDIDKeyFromDID(encoded, tc.op)
+			if err != nil {
+				t.Fatalf("NewDIDKeyFromDID(%q): %v", encoded, err)
+			}
+
+			if decoded.DID() != encoded {
+				t.Fatalf("round trip mismatch: got %q, want %q", decoded.DID(), encoded)
+			}
+		})
+	}
+}
+
+func TestNewDIDKeyFromDIDRejectsMalformedInput(t *testing.T) {
+	cases := []struct {
+		name string
+		did  string
+	}{
+		{"not enough segments", "did:key"},
+		{"wrong scheme", "foo:key:zabc"},
+		{"wrong method", "did:foo:zabc"},
+		{"empty id", "did:key:"},
+		{"missing z prefix", "did:key:abc"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewDIDKeyFromDID(tc.did, KeyOpVerify); err == nil {
+				t.Fatalf("expected an error for %q, got nil", tc.did)
+			}
+		})
+	}
+}
+
+func TestSignVerifyRoundTrip(t *testing.T) {
+	cases := []struct {
+		name     string
+		keyType  KeyType
+		byteSize int
+	}{
+		{"P256", KeyTypeP256, 32},
+		{"P384", KeyTypeP384, 48},
+		{"P521", KeyTypeP521, 66},
+		{"Secp256k1", KeyTypeSecp256k1, 32},
+		{"Ed25519", KeyTypeEd25519, 32},
+	}
+
+	msg := []byte("didkey signing test message")
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			did, err := NewDIDKeyFromPrivateKey(tc.keyType, randomPrivateKey(t, tc.keyType, tc.byteSize), KeyOpSign)
+			if err != nil {
+				t.Fatalf("NewDIDKeyFromPrivateKey: %v", err)
+			}
+
+			sig, err := did.Sign(msg)
+			if err != nil {
+				t.Fatalf("Sign: %v", err)
+			}
+
+			if !did.Verify(msg, sig) {
+				t.Fatalf("Verify returned false for a freshly produced signature")
+			}
+
+			if did.Verify([]byte("a different message"), sig) {
+				t.Fatalf("Verify returned true for a tampered message")
+			}
+		})
+	}
+}
+
+func TestX25519RejectsSignAndVerify(t *testing.T) {
+	did, err := NewDIDKeyFromPrivateKey(KeyTypeX25519, randomPrivateKey(t, KeyTypeX25519, 32), KeyOpDeriveKey)
+	if err != nil {
+		t.Fatalf("NewDIDKeyFromPrivateKey: %v", err)
+	}
+
+	if _, err := did.Sign([]byte("msg")); err == nil {
+		t.Fatalf("expected Sign to fail for an X25519 key")
+	}
+
+	if did.Verify([]byte("msg"), make([]byte, 64)) {
+		t.Fatalf("expected Verify to return false for an X25519 key")
+	}
+}
@@ -0,0 +1,105 @@
+package didkey
+
+import (
+	"crypto/elliptic"
+	"crypto/sha256"
+	"io"
+	"math/big"
+
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+)
+
+// curvePoint is implemented by public keys whose material is a point on an
+// elliptic.Curve, which is promoted onto secp256k1PublicKey through its
+// embedded ecdsaPublicKey.
+type curvePoint interface {
+	point() (curve elliptic.Curve, x, y *big.Int)
+}
+
+// curveScalar is implemented by private keys whose material is a scalar on
+// an elliptic.Curve, which is promoted onto secp256k1PrivateKey through its
+// embedded ecdsaPrivateKey.
+type curveScalar interface {
+	scalar() (curve elliptic.Curve, d *big.Int)
+}
+
+func (k *ecdsaPublicKey) point() (elliptic.Curve, *big.Int, *big.Int) {
+	return k.curve.curve, k.x, k.y
+}
+
+func (k *ecdsaPrivateKey) scalar() (elliptic.Curve, *big.Int) {
+	return k.curve.curve, k.d
+}
+
+// SharedSecret performs ECDH between did and peer, returning the X
+// coordinate of did.PrivateKey.D * peer.PublicKey padded to the curve's
+// byte size. X25519 keys use the dedicated X25519 ladder instead.
+func (did DIDKey) SharedSecret(peer *DIDKey) ([]byte, error) {
+	if did.PrivateKey == nil {
+		return nil, ErrNoPrivateKey
+	}
+
+	if priv, ok := did.PrivateKey.(*x25519PrivateKey); ok {
+		return x25519SharedSecret(priv, peer.PublicKey)
+	}
+
+	priv, ok := did.PrivateKey.(curveScalar)
+	if !ok {
+		return nil, ErrCurveMismatch
+	}
+
+	pub, ok := peer.PublicKey.(curvePoint)
+	if !ok {
+		return nil, ErrCurveMismatch
+	}
+
+	privCurve, d := priv.scalar()
+	pubCurve, x, y := pub.point()
+	if privCurve != pubCurve {
+		return nil, ErrCurveMismatch
+	}
+
+	sx, sy := privCurve.ScalarMult(x, y, d.Bytes())
+	if sx == nil || sy == nil || (sx.Sign() == 0 && sy.Sign() == 0) {
+		return nil, ErrInvalidSharedSecret
+	}
+
+	out := make([]byte, curveByteSize(privCurve))
+	sx.FillBytes(out)
+
+	return out, nil
+}
+
+func x25519SharedSecret(priv *x25519PrivateKey, peer PublicKey) ([]byte, error) {
+	pub, ok := peer.(*x25519PublicKey)
+	if !ok {
+		return nil, ErrCurveMismatch
+	}
+
+	secret, err := curve25519.X25519(priv.key, pub.key)
+	if err != nil {
+		return nil, ErrInvalidSharedSecret
+	}
+
+	return secret, nil
+}
+
+// DeriveKey runs HKDF-SHA256 over the raw ECDH shared secret between did and
+// peer, returning length bytes of key material bound to info. This lets
+// callers build ECIES-style envelope encryption or authenticated channels on
+// top of did:key identifiers without handling the raw shared secret
+// themselves.
+func (did DIDKey) DeriveKey(peer *DIDKey, info []byte, length int) ([]byte, error) {
+	secret, err := did.SharedSecret(peer)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, nil, info), out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
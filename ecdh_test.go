@@ -0,0 +1,103 @@
+package didkey
+
+import "testing"
+
+func TestSharedSecretSymmetric(t *testing.T) {
+	cases := []struct {
+		name     string
+		keyType  KeyType
+		byteSize int
+		op       KeyOp
+	}{
+		{"P256", KeyTypeP256, 32, KeyOpDeriveKey},
+		{"P384", KeyTypeP384, 48, KeyOpDeriveKey},
+		{"Secp256k1", KeyTypeSecp256k1, 32, KeyOpDeriveKey},
+		{"X25519", KeyTypeX25519, 32, KeyOpDeriveKey},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a, err := NewDIDKeyFromPrivateKey(tc.keyType, randomPrivateKey(t, tc.keyType, tc.byteSize), tc.op)
+			if err != nil {
+				t.Fatalf("NewDIDKeyFromPrivateKey(a): %v", err)
+			}
+			b, err := NewDIDKeyFromPrivateKey(tc.keyType, randomPrivateKey(t, tc.keyType, tc.byteSize), tc.op)
+			if err != nil {
+				t.Fatalf("NewDIDKeyFromPrivateKey(b): %v", err)
+			}
+
+			secretAB, err := a.SharedSecret(b)
+			if err != nil {
+				t.Fatalf("a.SharedSecret(b): %v", err)
+			}
+			secretBA, err := b.SharedSecret(a)
+			if err != nil {
+				t.Fatalf("b.SharedSecret(a): %v", err)
+			}
+
+			if string(secretAB) != string(secretBA) {
+				t.Fatalf("shared secrets do not match: %x != %x", secretAB, secretBA)
+			}
+		})
+	}
+}
+
+func TestSharedSecretCurveMismatch(t *testing.T) {
+	p256, err := NewDIDKeyFromPrivateKey(KeyTypeP256, randomPrivateKey(t, KeyTypeP256, 32), KeyOpDeriveKey)
+	if err != nil {
+		t.Fatalf("NewDIDKeyFromPrivateKey(p256): %v", err)
+	}
+	p384, err := NewDIDKeyFromPrivateKey(KeyTypeP384, randomPrivateKey(t, KeyTypeP384, 48), KeyOpDeriveKey)
+	if err != nil {
+		t.Fatalf("NewDIDKeyFromPrivateKey(p384): %v", err)
+	}
+
+	if _, err := p256.SharedSecret(p384); err != ErrCurveMismatch {
+		t.Fatalf("expected ErrCurveMismatch, got %v", err)
+	}
+}
+
+func TestSharedSecretNoPrivateKey(t *testing.T) {
+	priv, err := NewDIDKeyFromPrivateKey(KeyTypeP256, randomPrivateKey(t, KeyTypeP256, 32), KeyOpDeriveKey)
+	if err != nil {
+		t.Fatalf("NewDIDKeyFromPrivateKey: %v", err)
+	}
+
+	pubOnly, err := NewDIDKeyFromDID(priv.DID(), KeyOpDeriveKey)
+	if err != nil {
+		t.Fatalf("NewDIDKeyFromDID: %v", err)
+	}
+
+	if _, err := pubOnly.SharedSecret(priv); err != ErrNoPrivateKey {
+		t.Fatalf("expected ErrNoPrivateKey, got %v", err)
+	}
+}
+
+func TestDeriveKeyLengthAndDeterminism(t *testing.T) {
+	a, err := NewDIDKeyFromPrivateKey(KeyTypeX25519, randomPrivateKey(t, KeyTypeX25519, 32), KeyOpDeriveKey)
+	if err != nil {
+		t.Fatalf("NewDIDKeyFromPrivateKey(a): %v", err)
+	}
+	b, err := NewDIDKeyFromPrivateKey(KeyTypeX25519, randomPrivateKey(t, KeyTypeX25519, 32), KeyOpDeriveKey)
+	if err != nil {
+		t.Fatalf("NewDIDKeyFromPrivateKey(b): %v", err)
+	}
+
+	info := []byte("didkey ecdh test")
+
+	k1, err := a.DeriveKey(b, info, 42)
+	if err != nil {
+		t.Fatalf("DeriveKey: %v", err)
+	}
+	if len(k1) != 42 {
+		t.Fatalf("expected 42 bytes, got %d", len(k1))
+	}
+
+	k2, err := a.DeriveKey(b, info, 42)
+	if err != nil {
+		t.Fatalf("DeriveKey: %v", err)
+	}
+	if string(k1) != string(k2) {
+		t.Fatalf("DeriveKey is not deterministic for the same inputs")
+	}
+}
@@ -0,0 +1,191 @@
+package didkey
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+)
+
+// ecdsaCurve binds a standard library elliptic.Curve to the multicodec code
+// and compressed-point length did:key uses to encode it.
+type ecdsaCurve struct {
+	curve           elliptic.Curve
+	codec           Code
+	compressedBytes int
+}
+
+var (
+	p256Curve = ecdsaCurve{curve: elliptic.P256(), codec: P256Pub, compressedBytes: 33}
+	p384Curve = ecdsaCurve{curve: elliptic.P384(), codec: P384Pub, compressedBytes: 49}
+	p521Curve = ecdsaCurve{curve: elliptic.P521(), codec: P521Pub, compressedBytes: 67}
+)
+
+type ecdsaPublicKey struct {
+	curve ecdsaCurve
+	x, y  *big.Int
+}
+
+func (k *ecdsaPublicKey) Verify(msg, signature []byte) bool {
+	byteSize := curveByteSize(k.curve.curve)
+	if len(signature) != byteSize*2 {
+		return false
+	}
+
+	r := new(big.Int).SetBytes(signature[:byteSize])
+	s := new(big.Int).SetBytes(signature[byteSize:])
+
+	n := k.curve.curve.Params().N
+	if r.Sign() <= 0 || r.Cmp(n) >= 0 || s.Sign() <= 0 || s.Cmp(n) >= 0 {
+		return false
+	}
+
+	scheme, err := signatureSchemeFor(k)
+	if err != nil {
+		return false
+	}
+
+	pub := ecdsa.PublicKey{Curve: k.curve.curve, X: k.x, Y: k.y}
+	return ecdsa.Verify(&pub, scheme.hash(msg), r, s)
+}
+
+func (k *ecdsaPublicKey) Multicodec() Code {
+	return k.curve.codec
+}
+
+func (k *ecdsaPublicKey) Marshal() []byte {
+	return elliptic.MarshalCompressed(k.curve.curve, k.x, k.y)
+}
+
+type ecdsaPrivateKey struct {
+	curve ecdsaCurve
+	d     *big.Int
+	pub   *ecdsaPublicKey
+}
+
+func (k *ecdsaPrivateKey) Sign(msg []byte) ([]byte, error) {
+	scheme, err := signatureSchemeFor(k.pub)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{Curve: k.curve.curve, X: k.pub.x, Y: k.pub.y},
+		D:         k.d,
+	}
+
+	r, s, err := ecdsa.Sign(rand.Reader, key, scheme.hash(msg))
+	if err != nil {
+		return nil, err
+	}
+
+	byteSize := curveByteSize(k.curve.curve)
+	sig := make([]byte, byteSize*2)
+	r.FillBytes(sig[0:byteSize])
+	s.FillBytes(sig[byteSize:])
+
+	return sig, nil
+}
+
+// SignDeterministic signs msg the same way Sign does, but derives its
+// per-signature nonce with RFC 6979 instead of reading from rand.Reader, so
+// the same (key, msg) pair always produces the same signature.
+func (k *ecdsaPrivateKey) SignDeterministic(msg []byte) ([]byte, error) {
+	scheme, err := signatureSchemeFor(k.pub)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := scheme.hash(msg)
+	n := k.curve.curve.Params().N
+	byteSize := curveByteSize(k.curve.curve)
+	e := bitsToInt(digest, n.BitLen())
+
+	for attempt := 0; ; attempt++ {
+		kVal := deterministicNonce(n, k.d, digest, scheme.hasher(), attempt)
+		if kVal == nil {
+			return nil, fmt.Errorf("rfc6979: failed to derive a nonce")
+		}
+
+		rx, _ := k.curve.curve.ScalarBaseMult(kVal.Bytes())
+		r := new(big.Int).Mod(rx, n)
+		if r.Sign() == 0 {
+			continue
+		}
+
+		kInv := new(big.Int).ModInverse(kVal, n)
+		if kInv == nil {
+			continue
+		}
+
+		s := new(big.Int).Mul(k.d, r)
+		s.Add(s, e)
+		s.Mul(s, kInv)
+		s.Mod(s, n)
+		if s.Sign() == 0 {
+			continue
+		}
+
+		sig := make([]byte, byteSize*2)
+		r.FillBytes(sig[0:byteSize])
+		s.FillBytes(sig[byteSize:])
+
+		return sig, nil
+	}
+}
+
+func (k *ecdsaPrivateKey) Public() PublicKey {
+	return k.pub
+}
+
+func (k *ecdsaPrivateKey) Marshal() []byte {
+	byteSize := curveByteSize(k.curve.curve)
+	buf := make([]byte, byteSize)
+	k.d.FillBytes(buf)
+	return buf
+}
+
+func curveByteSize(curve elliptic.Curve) int {
+	byteSize := curve.Params().BitSize / 8
+	if curve.Params().BitSize%8 > 0 {
+		byteSize += 1
+	}
+	return byteSize
+}
+
+func newECDSAPrivateKey(curve ecdsaCurve, privateKey []byte) (PrivateKey, error) {
+	x, y := curve.curve.ScalarBaseMult(privateKey)
+
+	pub := &ecdsaPublicKey{curve: curve, x: x, y: y}
+	return &ecdsaPrivateKey{
+		curve: curve,
+		d:     new(big.Int).SetBytes(privateKey),
+		pub:   pub,
+	}, nil
+}
+
+func parseECDSAPublicKey(curve ecdsaCurve, raw []byte) (PublicKey, error) {
+	if len(raw) != curve.compressedBytes {
+		return nil, fmt.Errorf("invalid did key; decoded bytes must be %d bytes", curve.compressedBytes)
+	}
+
+	x, y := elliptic.UnmarshalCompressed(curve.curve, raw)
+	if x == nil || y == nil {
+		return nil, fmt.Errorf("invalid did key; point is not on the curve")
+	}
+
+	return &ecdsaPublicKey{curve: curve, x: x, y: y}, nil
+}
+
+func parseP256PublicKey(raw []byte) (PublicKey, error) {
+	return parseECDSAPublicKey(p256Curve, raw)
+}
+
+func parseP384PublicKey(raw []byte) (PublicKey, error) {
+	return parseECDSAPublicKey(p384Curve, raw)
+}
+
+func parseP521PublicKey(raw []byte) (PublicKey, error) {
+	return parseECDSAPublicKey(p521Curve, raw)
+}
@@ -0,0 +1,71 @@
+package didkey
+
+import (
+	"crypto/ed25519"
+	"fmt"
+)
+
+type ed25519PublicKey struct {
+	key ed25519.PublicKey
+}
+
+// Verify checks a raw 64-byte Ed25519 signature; unlike ECDSA there is no
+// r||s split to undo, and EdDSA hashes msg itself rather than expecting a
+// pre-computed digest.
+func (k *ed25519PublicKey) Verify(msg, signature []byte) bool {
+	if len(signature) != ed25519.SignatureSize {
+		return false
+	}
+
+	return ed25519.Verify(k.key, msg, signature)
+}
+
+func (k *ed25519PublicKey) Multicodec() Code {
+	return Ed25519Pub
+}
+
+func (k *ed25519PublicKey) Marshal() []byte {
+	return []byte(k.key)
+}
+
+type ed25519PrivateKey struct {
+	key ed25519.PrivateKey
+	pub *ed25519PublicKey
+}
+
+func (k *ed25519PrivateKey) Sign(msg []byte) ([]byte, error) {
+	return ed25519.Sign(k.key, msg), nil
+}
+
+// SignDeterministic is identical to Sign: EdDSA signatures are already
+// deterministic, so there is no separate RFC 6979-style nonce to derive.
+func (k *ed25519PrivateKey) SignDeterministic(msg []byte) ([]byte, error) {
+	return k.Sign(msg)
+}
+
+func (k *ed25519PrivateKey) Public() PublicKey {
+	return k.pub
+}
+
+func (k *ed25519PrivateKey) Marshal() []byte {
+	return []byte(k.key.Seed())
+}
+
+func newEd25519PrivateKey(seed []byte) (PrivateKey, error) {
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("invalid ed25519 private key; seed must be %d bytes", ed25519.SeedSize)
+	}
+
+	key := ed25519.NewKeyFromSeed(seed)
+	pub := &ed25519PublicKey{key: key.Public().(ed25519.PublicKey)}
+
+	return &ed25519PrivateKey{key: key, pub: pub}, nil
+}
+
+func parseEd25519PublicKey(raw []byte) (PublicKey, error) {
+	if len(raw) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid did key; decoded bytes must be %d bytes", ed25519.PublicKeySize)
+	}
+
+	return &ed25519PublicKey{key: ed25519.PublicKey(raw)}, nil
+}
@@ -0,0 +1,37 @@
+package didkey
+
+import "errors"
+
+var (
+	// ErrCurveMismatch is returned when an operation is attempted between
+	// two keys that do not share a compatible curve or key type.
+	ErrCurveMismatch = errors.New("didkey: keys do not share a compatible curve")
+
+	// ErrNoPrivateKey is returned when an operation requires a private key
+	// but the DIDKey only carries a public key.
+	ErrNoPrivateKey = errors.New("didkey: did key has no private key")
+
+	// ErrInvalidSharedSecret is returned when an ECDH exchange yields the
+	// point at infinity (or, for X25519, an all-zero output).
+	ErrInvalidSharedSecret = errors.New("didkey: shared secret is invalid")
+
+	// ErrInvalidScalar is returned when a private scalar is zero, negative,
+	// or not reduced modulo the curve order.
+	ErrInvalidScalar = errors.New("didkey: private scalar out of range")
+
+	// ErrPointNotOnCurve is returned when a public key's coordinates are the
+	// point at infinity or do not satisfy the curve equation.
+	ErrPointNotOnCurve = errors.New("didkey: public point is not on the curve")
+
+	// ErrKeyOpMismatch is returned when a key is used for an operation its
+	// key type does not support, e.g. signing with an X25519 key.
+	ErrKeyOpMismatch = errors.New("didkey: key does not support the requested operation")
+
+	// ErrInvalidRecoveryID is returned when a recoverable signature's
+	// recovery id (v) is missing or out of the valid 0-3 range.
+	ErrInvalidRecoveryID = errors.New("didkey: invalid recovery id")
+
+	// ErrNoRecoveredKey is returned when a recoverable signature does not
+	// correspond to a valid curve point for any candidate recovery id.
+	ErrNoRecoveredKey = errors.New("didkey: could not recover a public key from signature")
+)
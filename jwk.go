@@ -0,0 +1,271 @@
+package didkey
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+// jwk is the subset of RFC 7517 JSON Web Key parameters did:key needs for
+// the EC (P-256/P-384/P-521/secp256k1) and OKP (Ed25519/X25519) key types.
+type jwk struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y,omitempty"`
+	D   string `json:"d,omitempty"`
+}
+
+// JWK encodes did as a JSON Web Key, including the private "d" parameter
+// when did carries a private key.
+func (did DIDKey) JWK() ([]byte, error) {
+	j, err := jwkFromDIDKey(did)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(j)
+}
+
+// ParseJWK decodes a JSON Web Key into a DIDKey, validating that the
+// advertised curve matches the key type, that the coordinates fit the
+// curve's field size, and that they are non-zero and on the curve.
+func ParseJWK(data []byte) (*DIDKey, error) {
+	var j jwk
+	if err := json.Unmarshal(data, &j); err != nil {
+		return nil, err
+	}
+
+	switch j.Kty {
+	case "EC":
+		return ecDIDKeyFromJWK(&j)
+	case "OKP":
+		return okpDIDKeyFromJWK(&j)
+	default:
+		return nil, fmt.Errorf("jwk: unsupported kty %q", j.Kty)
+	}
+}
+
+func jwkFromDIDKey(did DIDKey) (*jwk, error) {
+	switch pub := did.PublicKey.(type) {
+	case *ecdsaPublicKey:
+		return ecJWK(pub.curve, pub.x, pub.y, did.PrivateKey)
+	case *secp256k1PublicKey:
+		return ecJWK(pub.curve, pub.x, pub.y, did.PrivateKey)
+	case *ed25519PublicKey:
+		j := &jwk{Kty: "OKP", Crv: "Ed25519", X: base64.RawURLEncoding.EncodeToString(pub.key)}
+		if priv, ok := did.PrivateKey.(*ed25519PrivateKey); ok {
+			j.D = base64.RawURLEncoding.EncodeToString(priv.key.Seed())
+		}
+		return j, nil
+	case *x25519PublicKey:
+		j := &jwk{Kty: "OKP", Crv: "X25519", X: base64.RawURLEncoding.EncodeToString(pub.key)}
+		if priv, ok := did.PrivateKey.(*x25519PrivateKey); ok {
+			j.D = base64.RawURLEncoding.EncodeToString(priv.key)
+		}
+		return j, nil
+	default:
+		return nil, fmt.Errorf("jwk: unsupported key type %T", did.PublicKey)
+	}
+}
+
+func ecJWK(curve ecdsaCurve, x, y *big.Int, priv PrivateKey) (*jwk, error) {
+	name, err := jwkCurveName(curve.codec)
+	if err != nil {
+		return nil, err
+	}
+
+	byteSize := curveByteSize(curve.curve)
+	xb, yb := make([]byte, byteSize), make([]byte, byteSize)
+	x.FillBytes(xb)
+	y.FillBytes(yb)
+
+	j := &jwk{
+		Kty: "EC",
+		Crv: name,
+		X:   base64.RawURLEncoding.EncodeToString(xb),
+		Y:   base64.RawURLEncoding.EncodeToString(yb),
+	}
+
+	var d *big.Int
+	switch priv := priv.(type) {
+	case *ecdsaPrivateKey:
+		d = priv.d
+	case *secp256k1PrivateKey:
+		d = priv.d
+	}
+	if d != nil {
+		db := make([]byte, byteSize)
+		d.FillBytes(db)
+		j.D = base64.RawURLEncoding.EncodeToString(db)
+	}
+
+	return j, nil
+}
+
+func jwkCurveName(codec Code) (string, error) {
+	switch codec {
+	case P256Pub:
+		return "P-256", nil
+	case P384Pub:
+		return "P-384", nil
+	case P521Pub:
+		return "P-521", nil
+	case Secp256k1Pub:
+		return "secp256k1", nil
+	default:
+		return "", fmt.Errorf("jwk: unsupported multicodec %d", codec)
+	}
+}
+
+func ecdsaCurveFromJWKName(name string) (ecdsaCurve, error) {
+	switch name {
+	case "P-256":
+		return p256Curve, nil
+	case "P-384":
+		return p384Curve, nil
+	case "P-521":
+		return p521Curve, nil
+	case "secp256k1":
+		return secp256k1Curve, nil
+	default:
+		return ecdsaCurve{}, fmt.Errorf("jwk: unsupported crv %q", name)
+	}
+}
+
+func ecDIDKeyFromJWK(j *jwk) (*DIDKey, error) {
+	curve, err := ecdsaCurveFromJWKName(j.Crv)
+	if err != nil {
+		return nil, err
+	}
+
+	if j.X == "" || j.Y == "" {
+		return nil, fmt.Errorf("jwk: EC key requires x and y")
+	}
+
+	x, err := base64.RawURLEncoding.DecodeString(j.X)
+	if err != nil {
+		return nil, fmt.Errorf("jwk: invalid x: %w", err)
+	}
+	y, err := base64.RawURLEncoding.DecodeString(j.Y)
+	if err != nil {
+		return nil, fmt.Errorf("jwk: invalid y: %w", err)
+	}
+
+	byteSize := curveByteSize(curve.curve)
+	if len(x) != byteSize || len(y) != byteSize {
+		return nil, fmt.Errorf("jwk: x/y must be %d bytes for %s", byteSize, j.Crv)
+	}
+
+	xi, yi := new(big.Int).SetBytes(x), new(big.Int).SetBytes(y)
+	if xi.Sign() == 0 || yi.Sign() == 0 {
+		return nil, fmt.Errorf("jwk: x/y must be non-zero")
+	}
+	// IsOnCurve reduces its inputs mod P instead of rejecting them, so a
+	// non-canonical x or y (e.g. x0+P, still byteSize bytes) would otherwise
+	// be silently accepted as equivalent to x0. btcec's secp256k1 does this;
+	// reject out-of-range coordinates ourselves before trusting IsOnCurve.
+	p := curve.curve.Params().P
+	if xi.Cmp(p) >= 0 || yi.Cmp(p) >= 0 {
+		return nil, fmt.Errorf("jwk: x/y must be less than the field prime")
+	}
+	if !curve.curve.IsOnCurve(xi, yi) {
+		return nil, fmt.Errorf("jwk: point is not on the curve")
+	}
+
+	pub := wrapECDSAPublicKey(curve, xi, yi)
+
+	if j.D == "" {
+		return &DIDKey{PublicKey: pub}, nil
+	}
+
+	d, err := base64.RawURLEncoding.DecodeString(j.D)
+	if err != nil {
+		return nil, fmt.Errorf("jwk: invalid d: %w", err)
+	}
+	if len(d) != byteSize {
+		return nil, fmt.Errorf("jwk: d must be %d bytes for %s", byteSize, j.Crv)
+	}
+
+	priv := wrapECDSAPrivateKey(curve, new(big.Int).SetBytes(d))
+
+	return &DIDKey{PublicKey: priv.Public(), PrivateKey: priv}, nil
+}
+
+func okpDIDKeyFromJWK(j *jwk) (*DIDKey, error) {
+	if j.X == "" {
+		return nil, fmt.Errorf("jwk: OKP key requires x")
+	}
+
+	x, err := base64.RawURLEncoding.DecodeString(j.X)
+	if err != nil {
+		return nil, fmt.Errorf("jwk: invalid x: %w", err)
+	}
+
+	switch j.Crv {
+	case "Ed25519":
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("jwk: Ed25519 x must be %d bytes", ed25519.PublicKeySize)
+		}
+		if j.D == "" {
+			return &DIDKey{PublicKey: &ed25519PublicKey{key: ed25519.PublicKey(x)}}, nil
+		}
+
+		d, err := base64.RawURLEncoding.DecodeString(j.D)
+		if err != nil {
+			return nil, fmt.Errorf("jwk: invalid d: %w", err)
+		}
+		priv, err := newEd25519PrivateKey(d)
+		if err != nil {
+			return nil, err
+		}
+		return &DIDKey{PublicKey: priv.Public(), PrivateKey: priv}, nil
+
+	case "X25519":
+		if len(x) != x25519KeySize {
+			return nil, fmt.Errorf("jwk: X25519 x must be %d bytes", x25519KeySize)
+		}
+		if j.D == "" {
+			return &DIDKey{PublicKey: &x25519PublicKey{key: x}}, nil
+		}
+
+		d, err := base64.RawURLEncoding.DecodeString(j.D)
+		if err != nil {
+			return nil, fmt.Errorf("jwk: invalid d: %w", err)
+		}
+		priv, err := newX25519PrivateKey(d)
+		if err != nil {
+			return nil, err
+		}
+		return &DIDKey{PublicKey: priv.Public(), PrivateKey: priv}, nil
+
+	default:
+		return nil, fmt.Errorf("jwk: unsupported crv %q", j.Crv)
+	}
+}
+
+// wrapECDSAPublicKey wraps a curve point in the PublicKey implementation
+// appropriate for curve, since secp256k1 needs the low-S aware wrapper
+// rather than the bare ecdsaPublicKey.
+func wrapECDSAPublicKey(curve ecdsaCurve, x, y *big.Int) PublicKey {
+	pub := &ecdsaPublicKey{curve: curve, x: x, y: y}
+	if curve.codec == Secp256k1Pub {
+		return &secp256k1PublicKey{ecdsaPublicKey: *pub}
+	}
+	return pub
+}
+
+// wrapECDSAPrivateKey wraps a scalar in the PrivateKey implementation
+// appropriate for curve, since secp256k1 needs the low-S aware wrapper
+// rather than the bare ecdsaPrivateKey.
+func wrapECDSAPrivateKey(curve ecdsaCurve, d *big.Int) PrivateKey {
+	x, y := curve.curve.ScalarBaseMult(d.Bytes())
+	pub := &ecdsaPublicKey{curve: curve, x: x, y: y}
+	priv := &ecdsaPrivateKey{curve: curve, d: d, pub: pub}
+	if curve.codec == Secp256k1Pub {
+		return &secp256k1PrivateKey{ecdsaPrivateKey: *priv}
+	}
+	return priv
+}
@@ -0,0 +1,84 @@
+package didkey
+
+import (
+	"encoding/base64"
+	"math/big"
+	"testing"
+)
+
+func TestJWKRoundTrip(t *testing.T) {
+	cases := []struct {
+		name     string
+		keyType  KeyType
+		byteSize int
+		op       KeyOp
+	}{
+		{"P256", KeyTypeP256, 32, KeyOpSign},
+		{"Secp256k1", KeyTypeSecp256k1, 32, KeyOpSign},
+		{"Ed25519", KeyTypeEd25519, 32, KeyOpSign},
+		{"X25519", KeyTypeX25519, 32, KeyOpDeriveKey},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			did, err := NewDIDKeyFromPrivateKey(tc.keyType, randomPrivateKey(t, tc.keyType, tc.byteSize), tc.op)
+			if err != nil {
+				t.Fatalf("NewDIDKeyFromPrivateKey: %v", err)
+			}
+
+			encoded, err := did.JWK()
+			if err != nil {
+				t.Fatalf("JWK: %v", err)
+			}
+
+			decoded, err := ParseJWK(encoded)
+			if err != nil {
+				t.Fatalf("ParseJWK: %v", err)
+			}
+
+			if decoded.DID() != did.DID() {
+				t.Fatalf("round trip mismatch: got %q, want %q", decoded.DID(), did.DID())
+			}
+			if decoded.PrivateKey == nil {
+				t.Fatalf("expected round-tripped JWK to carry a private key")
+			}
+		})
+	}
+}
+
+func TestParseJWKRejectsNonCanonicalSecp256k1Coordinate(t *testing.T) {
+	x0, y0 := secp256k1SmallXPoint(t)
+
+	p := secp256k1Curve.curve.Params().P
+	byteSize := curveByteSize(secp256k1Curve.curve)
+
+	overflowedX := new(big.Int).Add(x0, p)
+	xb := make([]byte, byteSize)
+	overflowedX.FillBytes(xb)
+
+	yb := make([]byte, byteSize)
+	y0.FillBytes(yb)
+
+	j := &jwk{
+		Kty: "EC",
+		Crv: "secp256k1",
+		X:   base64.RawURLEncoding.EncodeToString(xb),
+		Y:   base64.RawURLEncoding.EncodeToString(yb),
+	}
+
+	if _, err := ecDIDKeyFromJWK(j); err == nil {
+		t.Fatalf("expected x = x0+P to be rejected, got nil error")
+	}
+}
+
+func TestParseJWKRejectsMalformedInput(t *testing.T) {
+	if _, err := ParseJWK([]byte(`{"kty":"EC","crv":"P-256","x":"","y":""}`)); err == nil {
+		t.Fatalf("expected empty x/y to be rejected")
+	}
+	if _, err := ParseJWK([]byte(`{"kty":"RSA"}`)); err == nil {
+		t.Fatalf("expected unsupported kty to be rejected")
+	}
+	if _, err := ParseJWK([]byte(`not json`)); err == nil {
+		t.Fatalf("expected invalid JSON to be rejected")
+	}
+}
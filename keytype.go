@@ -0,0 +1,94 @@
+package didkey
+
+import (
+	"fmt"
+)
+
+// PublicKey is implemented by every key type did:key knows how to encode. It
+// abstracts over the multicodec-prefixed, base58btc-encoded public key
+// material so that DIDKey does not need to know which curve or signature
+// scheme it is holding.
+type PublicKey interface {
+	// Verify reports whether signature is a valid signature of msg under
+	// this key, hashing msg with whatever scheme the key type uses (see
+	// SignatureScheme). Keys that are only usable for key agreement (e.g.
+	// X25519) always return false.
+	Verify(msg, signature []byte) bool
+
+	// Multicodec returns the multicodec code identifying this key's type,
+	// used as the did:key encoding prefix.
+	Multicodec() Code
+
+	// Marshal returns the key material encoded the way it is embedded in a
+	// did:key identifier, i.e. the bytes that follow the multicodec prefix.
+	Marshal() []byte
+}
+
+// PrivateKey is implemented by every key type did:key knows how to encode
+// that also carries private key material.
+type PrivateKey interface {
+	// Sign signs msg, returning a signature verifiable with the matching
+	// PublicKey.Verify. Keys that are only usable for key agreement (e.g.
+	// X25519) always return an error.
+	Sign(msg []byte) ([]byte, error)
+
+	// SignDeterministic signs msg the same way Sign does, but derives its
+	// nonce deterministically (RFC 6979 for ECDSA; EdDSA is already
+	// deterministic) instead of reading from a random source.
+	SignDeterministic(msg []byte) ([]byte, error)
+
+	// Public returns the public key corresponding to this private key.
+	Public() PublicKey
+
+	// Marshal returns the raw private key material (scalar or seed).
+	Marshal() []byte
+}
+
+// KeyType identifies which curve or signature scheme a private key should be
+// interpreted as when it is not already wrapped in a DIDKey, e.g. when
+// loading one from raw bytes.
+type KeyType int
+
+const (
+	KeyTypeP256 KeyType = iota
+	KeyTypeP384
+	KeyTypeP521
+	KeyTypeSecp256k1
+	KeyTypeEd25519
+	KeyTypeX25519
+)
+
+// pubKeyParser decodes the bytes that follow a multicodec prefix into a
+// concrete PublicKey implementation.
+type pubKeyParser func(raw []byte) (PublicKey, error)
+
+// codecs maps every multicodec code did:key supports to the parser able to
+// decode its key material. NewDIDKeyFromDID dispatches through this table
+// instead of hard-coding a single supported curve.
+var codecs = map[Code]pubKeyParser{
+	P256Pub:      parseP256PublicKey,
+	P384Pub:      parseP384PublicKey,
+	P521Pub:      parseP521PublicKey,
+	Secp256k1Pub: parseSecp256k1PublicKey,
+	Ed25519Pub:   parseEd25519PublicKey,
+	X25519Pub:    parseX25519PublicKey,
+}
+
+func newPrivateKeyFromBytes(keyType KeyType, privateKey []byte) (PrivateKey, error) {
+	switch keyType {
+	case KeyTypeP256:
+		return newECDSAPrivateKey(p256Curve, privateKey)
+	case KeyTypeP384:
+		return newECDSAPrivateKey(p384Curve, privateKey)
+	case KeyTypeP521:
+		return newECDSAPrivateKey(p521Curve, privateKey)
+	case KeyTypeSecp256k1:
+		return newSecp256k1PrivateKey(privateKey)
+	case KeyTypeEd25519:
+		return newEd25519PrivateKey(privateKey)
+	case KeyTypeX25519:
+		return newX25519PrivateKey(privateKey)
+	default:
+		return nil, fmt.Errorf("unsupported key type: %d", keyType)
+	}
+}
@@ -0,0 +1,97 @@
+// Package libp2p bridges did:key identifiers and libp2p node identities.
+// Go does not allow attaching methods to a type declared in another
+// package, so the DIDKey.Libp2pPubKey/PeerID methods described for this
+// bridge are exposed here as plain functions instead.
+package libp2p
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/x509"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil/base58"
+	lcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/yum45f/didkey"
+)
+
+// FromLibp2pPubKey converts a libp2p public key into a DIDKey. libp2p
+// encodes P-256 keys as ASN.1 DER SPKI and secp256k1 keys as X9.62
+// compressed points inside a protobuf envelope; both are re-serialized into
+// the compressed-point + multicodec + base58btc envelope did:key expects.
+func FromLibp2pPubKey(pub lcrypto.PubKey) (*didkey.DIDKey, error) {
+	raw, err := pub.Raw()
+	if err != nil {
+		return nil, fmt.Errorf("libp2p: failed to read public key: %w", err)
+	}
+
+	var code didkey.Code
+	var compressed []byte
+
+	switch pub.Type() {
+	case lcrypto.ECDSA:
+		key, err := x509.ParsePKIXPublicKey(raw)
+		if err != nil {
+			return nil, fmt.Errorf("libp2p: failed to parse ECDSA SPKI: %w", err)
+		}
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if !ok || ecKey.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("libp2p: only P-256 ECDSA keys are supported")
+		}
+		code = didkey.P256Pub
+		compressed = elliptic.MarshalCompressed(elliptic.P256(), ecKey.X, ecKey.Y)
+
+	case lcrypto.Secp256k1:
+		if _, err := btcec.ParsePubKey(raw); err != nil {
+			return nil, fmt.Errorf("libp2p: invalid secp256k1 public key: %w", err)
+		}
+		code = didkey.Secp256k1Pub
+		compressed = raw // libp2p already stores secp256k1 keys as a compressed point
+
+	default:
+		return nil, fmt.Errorf("libp2p: unsupported key type %v", pub.Type())
+	}
+
+	encoded := base58.Encode(didkey.EncodeMulticodec(code, compressed))
+
+	return didkey.NewDIDKeyFromDID(fmt.Sprintf("did:key:z%s", encoded), didkey.KeyOpVerify)
+}
+
+// ToLibp2pPubKey converts did into the libp2p public key representation:
+// ASN.1 DER SPKI for P-256, a raw compressed point for secp256k1.
+func ToLibp2pPubKey(did *didkey.DIDKey) (lcrypto.PubKey, error) {
+	switch did.PublicKey.Multicodec() {
+	case didkey.P256Pub:
+		x, y := elliptic.UnmarshalCompressed(elliptic.P256(), did.PublicKey.Marshal())
+		if x == nil {
+			return nil, fmt.Errorf("libp2p: invalid P-256 did:key")
+		}
+
+		der, err := x509.MarshalPKIXPublicKey(&ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y})
+		if err != nil {
+			return nil, err
+		}
+
+		return lcrypto.UnmarshalECDSAPublicKey(der)
+
+	case didkey.Secp256k1Pub:
+		return lcrypto.UnmarshalSecp256k1PublicKey(did.PublicKey.Marshal())
+
+	default:
+		return nil, fmt.Errorf("libp2p: key type %d has no libp2p representation", did.PublicKey.Multicodec())
+	}
+}
+
+// PeerID derives the libp2p PeerID for did, matching how libp2p hashes a
+// node's public key: multihash-identity for small protobuf envelopes,
+// SHA-256 otherwise.
+func PeerID(did *didkey.DIDKey) (peer.ID, error) {
+	pub, err := ToLibp2pPubKey(did)
+	if err != nil {
+		return "", err
+	}
+
+	return peer.IDFromPublicKey(pub)
+}
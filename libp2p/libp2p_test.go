@@ -0,0 +1,86 @@
+package libp2p
+
+import (
+	"crypto/rand"
+	"testing"
+
+	lcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/yum45f/didkey"
+)
+
+func TestFromLibp2pPubKeyRoundTrip(t *testing.T) {
+	cases := []struct {
+		name string
+		typ  int
+	}{
+		{"ECDSA", lcrypto.ECDSA},
+		{"Secp256k1", lcrypto.Secp256k1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			priv, pub, err := lcrypto.GenerateKeyPair(tc.typ, 0)
+			if err != nil {
+				t.Fatalf("GenerateKeyPair: %v", err)
+			}
+			_ = priv
+
+			did, err := FromLibp2pPubKey(pub)
+			if err != nil {
+				t.Fatalf("FromLibp2pPubKey: %v", err)
+			}
+
+			back, err := ToLibp2pPubKey(did)
+			if err != nil {
+				t.Fatalf("ToLibp2pPubKey: %v", err)
+			}
+
+			if !back.Equals(pub) {
+				t.Fatalf("round-tripped libp2p public key does not match the original")
+			}
+		})
+	}
+}
+
+func TestPeerIDMatchesLibp2p(t *testing.T) {
+	_, pub, err := lcrypto.GenerateKeyPair(lcrypto.ECDSA, 0)
+	if err != nil {
+		t.Fatalf("GenerateKeyPair: %v", err)
+	}
+
+	did, err := FromLibp2pPubKey(pub)
+	if err != nil {
+		t.Fatalf("FromLibp2pPubKey: %v", err)
+	}
+
+	got, err := PeerID(did)
+	if err != nil {
+		t.Fatalf("PeerID: %v", err)
+	}
+
+	want, err := peer.IDFromPublicKey(pub)
+	if err != nil {
+		t.Fatalf("peer.IDFromPublicKey: %v", err)
+	}
+
+	if got != want {
+		t.Fatalf("PeerID mismatch: got %s, want %s", got, want)
+	}
+}
+
+func TestToLibp2pPubKeyRejectsUnsupportedKeyType(t *testing.T) {
+	seed := make([]byte, 32)
+	if _, err := rand.Read(seed); err != nil {
+		t.Fatalf("rand.Read: %v", err)
+	}
+
+	did, err := didkey.NewDIDKeyFromPrivateKey(didkey.KeyTypeEd25519, seed, didkey.KeyOpSign)
+	if err != nil {
+		t.Fatalf("NewDIDKeyFromPrivateKey: %v", err)
+	}
+
+	if _, err := ToLibp2pPubKey(did); err == nil {
+		t.Fatalf("expected Ed25519 did:key to be rejected; libp2p bridge only supports P-256/secp256k1")
+	}
+}
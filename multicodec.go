@@ -0,0 +1,42 @@
+package didkey
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Code identifies a multicodec-registered key type, used as the prefix that
+// marks which curve/signature scheme a did:key's bytes decode as.
+// github.com/yum45f/multicodec only registers P256Pub, not the other key
+// types did:key needs, so this package defines the codes it requires and
+// their varint (de)serialization locally instead of importing that module.
+type Code uint64
+
+// did:key public key multicodec codes, per the multicodec table
+// (https://github.com/multiformats/multicodec/blob/master/table.csv).
+const (
+	P256Pub      Code = 0x1200
+	P384Pub      Code = 0x1201
+	P521Pub      Code = 0x1202
+	Secp256k1Pub Code = 0xe7
+	Ed25519Pub   Code = 0xed
+	X25519Pub    Code = 0xec
+)
+
+// EncodeMulticodec prepends code, varint-encoded, to data.
+func EncodeMulticodec(code Code, data []byte) []byte {
+	buf := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(buf, uint64(code))
+	return append(buf[:n], data...)
+}
+
+// ParseMulticodec reads a varint-encoded Code off the front of data,
+// returning the code and the bytes that follow it.
+func ParseMulticodec(data []byte) (Code, []byte, error) {
+	code, n := binary.Uvarint(data)
+	if n <= 0 {
+		return 0, nil, fmt.Errorf("multicodec: invalid varint")
+	}
+
+	return Code(code), data[n:], nil
+}
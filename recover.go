@@ -0,0 +1,60 @@
+package didkey
+
+import (
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+)
+
+// SignRecoverable signs digest and returns a 65-byte r||s||v signature from
+// which the signer's secp256k1 did:key can be recovered with Recover. Only
+// secp256k1 keys support recoverable signatures.
+func (did DIDKey) SignRecoverable(digest [32]byte) ([]byte, error) {
+	if did.PrivateKey == nil {
+		return nil, ErrNoPrivateKey
+	}
+
+	priv, ok := did.PrivateKey.(*secp256k1PrivateKey)
+	if !ok {
+		return nil, ErrCurveMismatch
+	}
+
+	d := make([]byte, 32)
+	priv.d.FillBytes(d)
+	btcPriv, _ := btcec.PrivKeyFromBytes(d)
+
+	// compact is header||r||s, where header = 27 + recovery id (the
+	// compressed-key flag, +4, is not requested here since did:key always
+	// encodes compressed points separately via the multicodec envelope).
+	compact := btcecdsa.SignCompact(btcPriv, digest[:], false)
+
+	sig := make([]byte, 65)
+	copy(sig[:64], compact[1:])
+	sig[64] = compact[0] - 27
+
+	return sig, nil
+}
+
+// Recover reconstructs the signer's secp256k1 did:key from digest and a
+// 65-byte r||s||v signature produced by SignRecoverable, following SEC1
+// §4.1.6: v selects which of the candidate curve points R is the one the
+// signer actually used.
+func Recover(digest [32]byte, sig []byte) (*DIDKey, error) {
+	if len(sig) != 65 || sig[64] > 3 {
+		return nil, ErrInvalidRecoveryID
+	}
+
+	compact := make([]byte, 65)
+	compact[0] = 27 + sig[64]
+	copy(compact[1:], sig[:64])
+
+	pub, _, err := btcecdsa.RecoverCompact(compact, digest[:])
+	if err != nil {
+		return nil, ErrNoRecoveredKey
+	}
+
+	ecKey := pub.ToECDSA()
+
+	return &DIDKey{
+		PublicKey: &secp256k1PublicKey{ecdsaPublicKey{curve: secp256k1Curve, x: ecKey.X, y: ecKey.Y}},
+	}, nil
+}
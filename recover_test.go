@@ -0,0 +1,78 @@
+package didkey
+
+import (
+	"crypto/sha256"
+	"testing"
+)
+
+func TestSignRecoverableRoundTrip(t *testing.T) {
+	did, err := NewDIDKeyFromPrivateKey(KeyTypeSecp256k1, randomPrivateKey(t, KeyTypeSecp256k1, 32), KeyOpSign)
+	if err != nil {
+		t.Fatalf("NewDIDKeyFromPrivateKey: %v", err)
+	}
+
+	digest := sha256.Sum256([]byte("recoverable signature test message"))
+
+	sig, err := did.SignRecoverable(digest)
+	if err != nil {
+		t.Fatalf("SignRecoverable: %v", err)
+	}
+	if len(sig) != 65 {
+		t.Fatalf("expected a 65-byte signature, got %d bytes", len(sig))
+	}
+
+	recovered, err := Recover(digest, sig)
+	if err != nil {
+		t.Fatalf("Recover: %v", err)
+	}
+
+	if recovered.DID() != did.DID() {
+		t.Fatalf("recovered did:key mismatch: got %q, want %q", recovered.DID(), did.DID())
+	}
+}
+
+func TestSignRecoverableRejectsNonSecp256k1(t *testing.T) {
+	did, err := NewDIDKeyFromPrivateKey(KeyTypeP256, randomPrivateKey(t, KeyTypeP256, 32), KeyOpSign)
+	if err != nil {
+		t.Fatalf("NewDIDKeyFromPrivateKey: %v", err)
+	}
+
+	digest := sha256.Sum256([]byte("msg"))
+	if _, err := did.SignRecoverable(digest); err != ErrCurveMismatch {
+		t.Fatalf("expected ErrCurveMismatch, got %v", err)
+	}
+}
+
+func TestRecoverRejectsInvalidRecoveryID(t *testing.T) {
+	digest := sha256.Sum256([]byte("msg"))
+
+	sig := make([]byte, 65)
+	sig[64] = 4
+
+	if _, err := Recover(digest, sig); err != ErrInvalidRecoveryID {
+		t.Fatalf("expected ErrInvalidRecoveryID for v=4, got %v", err)
+	}
+
+	if _, err := Recover(digest, make([]byte, 64)); err != ErrInvalidRecoveryID {
+		t.Fatalf("expected ErrInvalidRecoveryID for a 64-byte signature, got %v", err)
+	}
+}
+
+func TestRecoverRejectsWrongDigest(t *testing.T) {
+	did, err := NewDIDKeyFromPrivateKey(KeyTypeSecp256k1, randomPrivateKey(t, KeyTypeSecp256k1, 32), KeyOpSign)
+	if err != nil {
+		t.Fatalf("NewDIDKeyFromPrivateKey: %v", err)
+	}
+
+	digest := sha256.Sum256([]byte("original message"))
+	sig, err := did.SignRecoverable(digest)
+	if err != nil {
+		t.Fatalf("SignRecoverable: %v", err)
+	}
+
+	wrongDigest := sha256.Sum256([]byte("a different message"))
+	recovered, err := Recover(wrongDigest, sig)
+	if err == nil && recovered.DID() == did.DID() {
+		t.Fatalf("expected recovering a signature against the wrong digest to not yield the signer's did:key")
+	}
+}
@@ -0,0 +1,89 @@
+package didkey
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"hash"
+	"math/big"
+)
+
+// deterministicNonce implements the k generation algorithm from RFC 6979
+// §3.2: given the curve order n, private scalar d, and message digest h1,
+// it deterministically derives the ECDSA nonce a signer would otherwise
+// pick at random. hasher is the HMAC hash RFC 6979 drives its DRBG with,
+// and must match the scheme the digest was produced with. attempt selects
+// which of the (extremely rare) retry candidates to return, for the case
+// where an earlier candidate yielded r=0 or s=0.
+func deterministicNonce(n, d *big.Int, h1 []byte, hasher func() hash.Hash, attempt int) *big.Int {
+	rolen := (n.BitLen() + 7) / 8
+
+	bh := bitsToOctets(h1, n, rolen)
+	bx := append(int2octets(d, rolen), bh...)
+
+	size := hasher().Size()
+	v := bytes.Repeat([]byte{0x01}, size)
+	k := bytes.Repeat([]byte{0x00}, size)
+
+	k = hmacSum(hasher, k, append(append(append([]byte{}, v...), 0x00), bx...))
+	v = hmacSum(hasher, k, v)
+	k = hmacSum(hasher, k, append(append(append([]byte{}, v...), 0x01), bx...))
+	v = hmacSum(hasher, k, v)
+
+	valid := 0
+	for i := 0; i <= attempt+8; i++ {
+		var t []byte
+		for len(t) < rolen {
+			v = hmacSum(hasher, k, v)
+			t = append(t, v...)
+		}
+
+		// Candidates outside [1, n-1] are skipped (RFC 6979 §3.2 step h.3)
+		// and do not count towards attempt.
+		candidate := bitsToInt(t, n.BitLen())
+		if candidate.Sign() > 0 && candidate.Cmp(n) < 0 {
+			if valid == attempt {
+				return candidate
+			}
+			valid++
+		}
+
+		k = hmacSum(hasher, k, append(append([]byte{}, v...), 0x00))
+		v = hmacSum(hasher, k, v)
+	}
+
+	return nil
+}
+
+func hmacSum(hasher func() hash.Hash, key, msg []byte) []byte {
+	mac := hmac.New(hasher, key)
+	mac.Write(msg)
+	return mac.Sum(nil)
+}
+
+// bitsToInt is the RFC 6979 bits2int primitive: interpret the leftmost
+// qlen bits of b as a big-endian integer.
+func bitsToInt(b []byte, qlen int) *big.Int {
+	x := new(big.Int).SetBytes(b)
+	if blen := len(b) * 8; blen > qlen {
+		x.Rsh(x, uint(blen-qlen))
+	}
+
+	return x
+}
+
+// int2octets is the RFC 6979 int2octets primitive: encode x as a
+// big-endian, rolen-byte string.
+func int2octets(x *big.Int, rolen int) []byte {
+	buf := make([]byte, rolen)
+	x.FillBytes(buf)
+	return buf
+}
+
+// bitsToOctets is the RFC 6979 bits2octets primitive: bits2int followed by
+// a reduction mod n and re-encoding as octets.
+func bitsToOctets(b []byte, n *big.Int, rolen int) []byte {
+	z := bitsToInt(b, n.BitLen())
+	z.Mod(z, n)
+
+	return int2octets(z, rolen)
+}
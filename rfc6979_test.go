@@ -0,0 +1,85 @@
+package didkey
+
+import "testing"
+
+func TestSignDeterministicIsDeterministic(t *testing.T) {
+	cases := []struct {
+		name     string
+		keyType  KeyType
+		byteSize int
+	}{
+		{"P256", KeyTypeP256, 32},
+		{"P384", KeyTypeP384, 48},
+		{"P521", KeyTypeP521, 66},
+		{"Secp256k1", KeyTypeSecp256k1, 32},
+		{"Ed25519", KeyTypeEd25519, 32},
+	}
+
+	msg := []byte("rfc6979 determinism test message")
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			did, err := NewDIDKeyFromPrivateKey(tc.keyType, randomPrivateKey(t, tc.keyType, tc.byteSize), KeyOpSign)
+			if err != nil {
+				t.Fatalf("NewDIDKeyFromPrivateKey: %v", err)
+			}
+
+			sig1, err := did.SignDeterministic(msg)
+			if err != nil {
+				t.Fatalf("SignDeterministic: %v", err)
+			}
+			sig2, err := did.SignDeterministic(msg)
+			if err != nil {
+				t.Fatalf("SignDeterministic: %v", err)
+			}
+
+			if string(sig1) != string(sig2) {
+				t.Fatalf("SignDeterministic produced different signatures for the same (key, msg)")
+			}
+
+			if !did.Verify(msg, sig1) {
+				t.Fatalf("Verify returned false for a deterministic signature")
+			}
+		})
+	}
+}
+
+func TestVerifyRejectsOutOfRangeRS(t *testing.T) {
+	did, err := NewDIDKeyFromPrivateKey(KeyTypeP256, randomPrivateKey(t, KeyTypeP256, 32), KeyOpSign)
+	if err != nil {
+		t.Fatalf("NewDIDKeyFromPrivateKey: %v", err)
+	}
+
+	byteSize := curveByteSize(p256Curve.curve)
+	n := p256Curve.curve.Params().N
+
+	// r = s = n is exactly out of range (valid values are in [1, n-1]).
+	sig := make([]byte, byteSize*2)
+	n.FillBytes(sig[0:byteSize])
+	n.FillBytes(sig[byteSize:])
+
+	if did.Verify([]byte("msg"), sig) {
+		t.Fatalf("expected Verify to reject r=s=n")
+	}
+
+	zero := make([]byte, byteSize*2)
+	if did.Verify([]byte("msg"), zero) {
+		t.Fatalf("expected Verify to reject r=s=0")
+	}
+}
+
+func TestDeterministicNonceRetriesOnInvalidCandidate(t *testing.T) {
+	n := p256Curve.curve.Params().N
+	d := p256Curve.curve.Params().N
+	h1 := make([]byte, 32)
+
+	k0 := deterministicNonce(n, d, h1, ES256.hasher(), 0)
+	k1 := deterministicNonce(n, d, h1, ES256.hasher(), 1)
+
+	if k0 == nil || k1 == nil {
+		t.Fatalf("expected both attempts to yield a candidate nonce")
+	}
+	if k0.Cmp(k1) == 0 {
+		t.Fatalf("expected successive retry attempts to yield distinct nonces")
+	}
+}
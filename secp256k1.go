@@ -0,0 +1,101 @@
+package didkey
+
+import (
+	"crypto/elliptic"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+)
+
+// secp256k1Curve adapts btcec's S256, which implements the standard library
+// elliptic.Curve interface, so secp256k1 private keys and raw r||s signing
+// reuse the same ecdsaPrivateKey/ecdsaPublicKey machinery as the NIST
+// curves. Point decompression still goes through btcec.ParsePubKey below,
+// since the stdlib's generic elliptic.UnmarshalCompressed assumes a=-3 and
+// secp256k1 is a=0.
+var secp256k1Curve = ecdsaCurve{curve: btcec.S256(), codec: Secp256k1Pub, compressedBytes: 33}
+
+var secp256k1HalfOrder = new(big.Int).Rsh(btcec.S256().Params().N, 1)
+
+type secp256k1PublicKey struct {
+	ecdsaPublicKey
+}
+
+// Verify additionally rejects malleable high-S signatures, matching the
+// low-S convention enforced by Bitcoin/Ethereum verifiers.
+func (k *secp256k1PublicKey) Verify(msg, signature []byte) bool {
+	if len(signature) != 64 {
+		return false
+	}
+	if new(big.Int).SetBytes(signature[32:]).Cmp(secp256k1HalfOrder) > 0 {
+		return false
+	}
+
+	return k.ecdsaPublicKey.Verify(msg, signature)
+}
+
+type secp256k1PrivateKey struct {
+	ecdsaPrivateKey
+}
+
+// Sign normalizes s to the lower half of the curve order so that produced
+// signatures satisfy the low-S convention Verify enforces.
+func (k *secp256k1PrivateKey) Sign(msg []byte) ([]byte, error) {
+	sig, err := k.ecdsaPrivateKey.Sign(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	normalizeLowS(k.curve.curve, sig)
+
+	return sig, nil
+}
+
+// SignDeterministic normalizes s the same way Sign does.
+func (k *secp256k1PrivateKey) SignDeterministic(msg []byte) ([]byte, error) {
+	sig, err := k.ecdsaPrivateKey.SignDeterministic(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	normalizeLowS(k.curve.curve, sig)
+
+	return sig, nil
+}
+
+func normalizeLowS(curve elliptic.Curve, sig []byte) {
+	byteSize := curveByteSize(curve)
+	s := new(big.Int).SetBytes(sig[byteSize:])
+	if s.Cmp(secp256k1HalfOrder) > 0 {
+		s.Sub(curve.Params().N, s)
+		s.FillBytes(sig[byteSize:])
+	}
+}
+
+func (k *secp256k1PrivateKey) Public() PublicKey {
+	return &secp256k1PublicKey{ecdsaPublicKey{curve: k.pub.curve, x: k.pub.x, y: k.pub.y}}
+}
+
+func newSecp256k1PrivateKey(privateKey []byte) (PrivateKey, error) {
+	inner, err := newECDSAPrivateKey(secp256k1Curve, privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &secp256k1PrivateKey{*inner.(*ecdsaPrivateKey)}, nil
+}
+
+func parseSecp256k1PublicKey(raw []byte) (PublicKey, error) {
+	if len(raw) != secp256k1Curve.compressedBytes {
+		return nil, fmt.Errorf("invalid did key; decoded bytes must be %d bytes", secp256k1Curve.compressedBytes)
+	}
+
+	key, err := btcec.ParsePubKey(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid did key; point is not on the curve")
+	}
+
+	ecKey := key.ToECDSA()
+	return &secp256k1PublicKey{ecdsaPublicKey{curve: secp256k1Curve, x: ecKey.X, y: ecKey.Y}}, nil
+}
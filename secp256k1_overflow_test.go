@@ -0,0 +1,35 @@
+package didkey
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+)
+
+// secp256k1SmallXPoint returns a valid secp256k1 curve point whose x
+// coordinate is small enough that x+P (the field prime) still fits in
+// byteSize bytes. Shared by the JWK and COSE non-canonical-coordinate tests,
+// which both need a point they can push out of range without overflowing
+// the fixed-width buffer they encode it into.
+func secp256k1SmallXPoint(t *testing.T) (x, y *big.Int) {
+	t.Helper()
+
+	compressed := make([]byte, 33)
+	compressed[0] = 0x02
+
+	for candidate := int64(1); candidate < 1000; candidate++ {
+		big.NewInt(candidate).FillBytes(compressed[1:])
+
+		key, err := btcec.ParsePubKey(compressed)
+		if err != nil {
+			continue
+		}
+
+		ecKey := key.ToECDSA()
+		return ecKey.X, ecKey.Y
+	}
+
+	t.Fatalf("could not find a secp256k1 point with a small x coordinate")
+	return nil, nil
+}
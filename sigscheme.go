@@ -0,0 +1,82 @@
+package didkey
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+)
+
+// SignatureScheme identifies the hash algorithm (and, for EdDSA, the
+// absence of one) a key type uses when signing, matching the did:key / JOSE
+// alg registries.
+type SignatureScheme int
+
+const (
+	ES256 SignatureScheme = iota
+	ES384
+	ES512
+	ES256K
+	EdDSA
+)
+
+// hash returns the bytes Sign/Verify actually operate on for msg: the
+// scheme's digest for every ECDSA variant, or msg itself for EdDSA, which
+// hashes internally as part of signing.
+func (s SignatureScheme) hash(msg []byte) []byte {
+	switch s {
+	case ES256, ES256K:
+		h := sha256.Sum256(msg)
+		return h[:]
+	case ES384:
+		h := sha512.Sum384(msg)
+		return h[:]
+	case ES512:
+		h := sha512.Sum512(msg)
+		return h[:]
+	case EdDSA:
+		return msg
+	default:
+		return nil
+	}
+}
+
+// hasher returns the hash.Hash constructor RFC 6979 nonce generation should
+// use for this scheme. It is nil for EdDSA, which does not use RFC 6979.
+func (s SignatureScheme) hasher() func() hash.Hash {
+	switch s {
+	case ES256, ES256K:
+		return sha256.New
+	case ES384:
+		return sha512.New384
+	case ES512:
+		return sha512.New
+	default:
+		return nil
+	}
+}
+
+// signatureSchemeFor returns the SignatureScheme a key type signs/verifies
+// with, keyed off the same multicodec used to encode it in a did:key.
+func signatureSchemeFor(pub PublicKey) (SignatureScheme, error) {
+	switch pub.Multicodec() {
+	case P256Pub:
+		return ES256, nil
+	case P384Pub:
+		return ES384, nil
+	case P521Pub:
+		return ES512, nil
+	case Secp256k1Pub:
+		return ES256K, nil
+	case Ed25519Pub:
+		return EdDSA, nil
+	default:
+		return 0, fmt.Errorf("signature scheme: unsupported key type %T", pub)
+	}
+}
+
+// Scheme returns the signature scheme did signs/verifies with. X25519 keys,
+// which never sign, return an error.
+func (did DIDKey) Scheme() (SignatureScheme, error) {
+	return signatureSchemeFor(did.PublicKey)
+}
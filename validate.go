@@ -0,0 +1,104 @@
+package didkey
+
+// KeyOp identifies how a DIDKey is about to be used, so that Validate can
+// confirm the underlying key type actually supports it — e.g. rejecting
+// KeyOpSign/KeyOpVerify for an X25519 key-agreement-only key.
+type KeyOp int
+
+const (
+	KeyOpSign KeyOp = iota
+	KeyOpVerify
+	KeyOpDeriveKey
+)
+
+// keyOpAdvertiser is implemented by every PublicKey so that Validate can ask
+// a key type which operations it actually supports.
+type keyOpAdvertiser interface {
+	supportsKeyOp(op KeyOp) bool
+}
+
+// structuralValidator is implemented by key types that carry material whose
+// well-formedness needs to be checked beyond its length, i.e. the ECDSA
+// family: a public point must lie on the curve, a private scalar must be in
+// range.
+type structuralValidator interface {
+	validateStructure() error
+}
+
+// Validate checks that did is structurally well-formed (private scalars in
+// range, public points on their curve) and that its key type supports op.
+// Callers can use it to pin a loaded key to a single purpose, e.g. refusing
+// to sign with a key only ever meant for verification.
+func (did DIDKey) Validate(op KeyOp) error {
+	if err := validateStructure(did.PublicKey, did.PrivateKey); err != nil {
+		return err
+	}
+
+	if kos, ok := did.PublicKey.(keyOpAdvertiser); ok && !kos.supportsKeyOp(op) {
+		return ErrKeyOpMismatch
+	}
+
+	if op == KeyOpSign && did.PrivateKey == nil {
+		return ErrNoPrivateKey
+	}
+
+	return nil
+}
+
+// validateStructure checks pub and priv (when present) for well-formedness
+// without regard to KeyOp. Validate runs it on every key it checks,
+// including the ones NewDIDKeyFromDID and NewDIDKeyFromPrivateKey construct.
+func validateStructure(pub PublicKey, priv PrivateKey) error {
+	// The scalar is checked first: a zero scalar (or one equal to the curve
+	// order) derives the point at infinity, and should report
+	// ErrInvalidScalar rather than ErrPointNotOnCurve.
+	if priv != nil {
+		if v, ok := priv.(structuralValidator); ok {
+			if err := v.validateStructure(); err != nil {
+				return err
+			}
+		}
+	}
+	if v, ok := pub.(structuralValidator); ok {
+		if err := v.validateStructure(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (k *ecdsaPublicKey) validateStructure() error {
+	if k.x == nil || k.y == nil {
+		return ErrPointNotOnCurve
+	}
+	if k.x.Sign() == 0 && k.y.Sign() == 0 {
+		return ErrPointNotOnCurve
+	}
+	if !k.curve.curve.IsOnCurve(k.x, k.y) {
+		return ErrPointNotOnCurve
+	}
+
+	return nil
+}
+
+func (k *ecdsaPrivateKey) validateStructure() error {
+	n := k.curve.curve.Params().N
+	if k.d.Sign() <= 0 || k.d.Cmp(n) >= 0 {
+		return ErrInvalidScalar
+	}
+
+	return nil
+}
+
+func (k *ecdsaPublicKey) supportsKeyOp(op KeyOp) bool {
+	return true
+}
+
+func (k *ed25519PublicKey) supportsKeyOp(op KeyOp) bool {
+	return op == KeyOpSign || op == KeyOpVerify
+}
+
+func (k *x25519PublicKey) supportsKeyOp(op KeyOp) bool {
+	return op == KeyOpDeriveKey
+}
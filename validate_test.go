@@ -0,0 +1,59 @@
+package didkey
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestValidateRejectsUnsupportedKeyOp(t *testing.T) {
+	if _, err := NewDIDKeyFromPrivateKey(KeyTypeEd25519, randomPrivateKey(t, KeyTypeEd25519, 32), KeyOpDeriveKey); err != ErrKeyOpMismatch {
+		t.Fatalf("expected ErrKeyOpMismatch for Ed25519+KeyOpDeriveKey, got %v", err)
+	}
+
+	if _, err := NewDIDKeyFromPrivateKey(KeyTypeX25519, randomPrivateKey(t, KeyTypeX25519, 32), KeyOpSign); err != ErrKeyOpMismatch {
+		t.Fatalf("expected ErrKeyOpMismatch for X25519+KeyOpSign, got %v", err)
+	}
+
+	if _, err := NewDIDKeyFromPrivateKey(KeyTypeX25519, randomPrivateKey(t, KeyTypeX25519, 32), KeyOpVerify); err != ErrKeyOpMismatch {
+		t.Fatalf("expected ErrKeyOpMismatch for X25519+KeyOpVerify, got %v", err)
+	}
+}
+
+func TestValidateAllowsCompatibleKeyOp(t *testing.T) {
+	if _, err := NewDIDKeyFromPrivateKey(KeyTypeEd25519, randomPrivateKey(t, KeyTypeEd25519, 32), KeyOpSign); err != nil {
+		t.Fatalf("expected Ed25519+KeyOpSign to be valid, got %v", err)
+	}
+
+	if _, err := NewDIDKeyFromPrivateKey(KeyTypeX25519, randomPrivateKey(t, KeyTypeX25519, 32), KeyOpDeriveKey); err != nil {
+		t.Fatalf("expected X25519+KeyOpDeriveKey to be valid, got %v", err)
+	}
+}
+
+func TestValidateRejectsInvalidScalar(t *testing.T) {
+	did, err := NewDIDKeyFromPrivateKey(KeyTypeP256, randomPrivateKey(t, KeyTypeP256, 32), KeyOpSign)
+	if err != nil {
+		t.Fatalf("NewDIDKeyFromPrivateKey: %v", err)
+	}
+
+	priv := did.PrivateKey.(*ecdsaPrivateKey)
+	priv.d.Set(p256Curve.curve.Params().N)
+
+	if err := did.Validate(KeyOpSign); err != ErrInvalidScalar {
+		t.Fatalf("expected ErrInvalidScalar for a scalar equal to the curve order, got %v", err)
+	}
+}
+
+func TestValidateRejectsPointNotOnCurve(t *testing.T) {
+	priv, err := NewDIDKeyFromPrivateKey(KeyTypeP256, randomPrivateKey(t, KeyTypeP256, 32), KeyOpSign)
+	if err != nil {
+		t.Fatalf("NewDIDKeyFromPrivateKey: %v", err)
+	}
+
+	pub := priv.PublicKey.(*ecdsaPublicKey)
+	pub.y.Add(pub.y, big.NewInt(1))
+
+	did := &DIDKey{PublicKey: pub}
+	if err := did.Validate(KeyOpVerify); err != ErrPointNotOnCurve {
+		t.Fatalf("expected ErrPointNotOnCurve, got %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package didkey
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/curve25519"
+)
+
+const x25519KeySize = 32
+
+// x25519PublicKey is only usable for ECDH key agreement; it deliberately
+// does not implement a meaningful Verify.
+type x25519PublicKey struct {
+	key []byte
+}
+
+func (k *x25519PublicKey) Verify(msg, signature []byte) bool {
+	return false
+}
+
+func (k *x25519PublicKey) Multicodec() Code {
+	return X25519Pub
+}
+
+func (k *x25519PublicKey) Marshal() []byte {
+	return k.key
+}
+
+// x25519PrivateKey is only usable for ECDH key agreement; it deliberately
+// does not implement a meaningful Sign.
+type x25519PrivateKey struct {
+	key []byte
+	pub *x25519PublicKey
+}
+
+func (k *x25519PrivateKey) Sign(msg []byte) ([]byte, error) {
+	return nil, fmt.Errorf("x25519 keys do not support signing; use SharedSecret for key agreement")
+}
+
+func (k *x25519PrivateKey) SignDeterministic(msg []byte) ([]byte, error) {
+	return k.Sign(msg)
+}
+
+func (k *x25519PrivateKey) Public() PublicKey {
+	return k.pub
+}
+
+func (k *x25519PrivateKey) Marshal() []byte {
+	return k.key
+}
+
+func newX25519PrivateKey(key []byte) (PrivateKey, error) {
+	if len(key) != x25519KeySize {
+		return nil, fmt.Errorf("invalid x25519 private key; must be %d bytes", x25519KeySize)
+	}
+
+	pubBytes, err := curve25519.X25519(key, curve25519.Basepoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid x25519 private key: %w", err)
+	}
+
+	return &x25519PrivateKey{
+		key: key,
+		pub: &x25519PublicKey{key: pubBytes},
+	}, nil
+}
+
+func parseX25519PublicKey(raw []byte) (PublicKey, error) {
+	if len(raw) != x25519KeySize {
+		return nil, fmt.Errorf("invalid did key; decoded bytes must be %d bytes", x25519KeySize)
+	}
+
+	return &x25519PublicKey{key: raw}, nil
+}